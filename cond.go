@@ -0,0 +1,48 @@
+package lockable
+
+import "sync"
+
+// initCond lazily binds l's internal sync.Cond to l.L. It is safe to
+// call concurrently.
+func (l *Lockable[T]) initCond() {
+	l.condOnce.Do(func() {
+		l.cond = sync.NewCond(l.L)
+	})
+}
+
+// Wait locks l.L, then blocks until cond(&l.data) reports true,
+// waking only when another goroutine calls Signal or Broadcast; each
+// wakeup reacquires l.L before re-evaluating cond, exactly as
+// sync.Cond.Wait does. Once cond reports true, Wait unlocks l.L and
+// returns, so the caller must not already hold l.L when calling Wait,
+// and must not assume l.L is still held once Wait has returned.
+//
+// l.L is therefore only guaranteed held while cond itself runs: any
+// read of, or mutation to, *T that must happen atomically with cond
+// becoming true belongs inside cond, not after Wait returns.
+func (l *Lockable[T]) Wait(cond func(*T) bool) error {
+	l.checker.check()
+	l.initCond()
+
+	l.L.Lock()
+	defer l.L.Unlock()
+
+	for !cond(&l.data) {
+		l.cond.Wait()
+	}
+	return nil
+}
+
+// Signal wakes one goroutine blocked in Wait, if any.
+func (l *Lockable[T]) Signal() {
+	l.checker.check()
+	l.initCond()
+	l.cond.Signal()
+}
+
+// Broadcast wakes all goroutines blocked in Wait, if any.
+func (l *Lockable[T]) Broadcast() {
+	l.checker.check()
+	l.initCond()
+	l.cond.Broadcast()
+}
@@ -0,0 +1,73 @@
+package lockable_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/weiwenchen2022/lockable"
+)
+
+func TestLockableWaitSignal(t *testing.T) {
+	t.Parallel()
+
+	l := New[[]int](new(sync.Mutex), nil)
+
+	done := make(chan struct{})
+	go func() {
+		if err := l.Wait(func(q *[]int) bool { return len(*q) > 0 }); err != nil {
+			t.Error(err)
+		}
+		close(done)
+	}()
+
+	// give the waiter a chance to block before producing.
+	time.Sleep(10 * time.Millisecond)
+
+	_ = l.Do(false, func(q *[]int) error {
+		*q = append(*q, 1)
+		return nil
+	})
+	l.Signal()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Wait did not return after Signal")
+	}
+}
+
+func TestLockableWaitBroadcast(t *testing.T) {
+	t.Parallel()
+
+	l := New[bool](new(sync.Mutex), false)
+
+	const n = 5
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			_ = l.Wait(func(ready *bool) bool { return *ready })
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	_ = l.Do(false, func(ready *bool) error {
+		*ready = true
+		return nil
+	})
+	l.Broadcast()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("not all waiters woke after Broadcast")
+	}
+}
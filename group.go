@@ -0,0 +1,172 @@
+package lockable
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"unsafe"
+)
+
+// Group runs a batch of Lockable.Do-style calls concurrently and
+// collects the first error, in the spirit of golang.org/x/sync/errgroup.
+//
+// Calls are registered with the package-level Add function (Go does
+// not allow a generic method on a non-generic receiver), then run
+// together with Wait:
+//
+//	var g Group
+//	Add(&g, l1, false, f1)
+//	Add(&g, l2, true, f2)
+//	err := g.Wait()
+//
+// The zero value is a valid, empty Group.
+type Group struct {
+	mu   sync.Mutex
+	work []func(ctx context.Context) error
+}
+
+// Add registers a call to l.DoContext(ctx, readOnly, f) to be run by
+// a future call to g.Wait.
+func Add[T any](g *Group, l *Lockable[T], readOnly bool, f func(*T) error) {
+	g.mu.Lock()
+	g.work = append(g.work, func(ctx context.Context) error {
+		return l.DoContext(ctx, readOnly, f)
+	})
+	g.mu.Unlock()
+}
+
+// Wait runs every call added with Add concurrently, cancelling the
+// others as soon as one returns a non-nil error, and returns the
+// first such error (or nil if all succeeded).
+func (g *Group) Wait() error {
+	g.mu.Lock()
+	work := g.work
+	g.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errOnce := make(chan error, 1)
+	var wg sync.WaitGroup
+	wg.Add(len(work))
+	for _, do := range work {
+		do := do
+		go func() {
+			defer wg.Done()
+			if err := do(ctx); err != nil {
+				select {
+				case errOnce <- err:
+					cancel()
+				default:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errOnce:
+		return err
+	default:
+		return nil
+	}
+}
+
+// DoAll runs f(i, &ls[i].data) concurrently for every Lockable in ls,
+// guarded by each Lockable's own Do semantics. If any call returns an
+// error, the remaining calls are cancelled via ctx and the first
+// non-nil error is returned.
+func DoAll[T any](ctx context.Context, ls []*Lockable[T], readOnly bool, f func(int, *T) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errOnce := make(chan error, 1)
+	var wg sync.WaitGroup
+	wg.Add(len(ls))
+	for i, l := range ls {
+		i, l := i, l
+		go func() {
+			defer wg.Done()
+			err := l.DoContext(ctx, readOnly, func(v *T) error {
+				return f(i, v)
+			})
+			if err != nil {
+				select {
+				case errOnce <- err:
+					cancel()
+				default:
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errOnce:
+		return err
+	default:
+		return nil
+	}
+}
+
+// DoOrdered locks every distinct Lockable in ls in a stable,
+// address-derived order, calls f once per Lockable while all of them
+// remain held, and unlocks them in reverse order before returning.
+// Acquiring in a fixed global order regardless of the order ls is
+// given in is what makes this deadlock-free when multiple goroutines
+// call DoOrdered over overlapping sets of Lockables. Duplicate
+// pointers in ls are deduped before locking, so the same Lockable is
+// never locked, or passed to f, more than once.
+func DoOrdered[T any](ls []*Lockable[T], f func(*T) error) (err error) {
+	sorted := make([]*Lockable[T], 0, len(ls))
+	seen := make(map[*Lockable[T]]bool, len(ls))
+	for _, l := range ls {
+		if !seen[l] {
+			seen[l] = true
+			sorted = append(sorted, l)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return uintptr(unsafe.Pointer(sorted[i])) < uintptr(unsafe.Pointer(sorted[j]))
+	})
+
+	locked := make([]sync.Locker, 0, len(sorted))
+	defer func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			locked[i].Unlock()
+		}
+	}()
+
+	for _, l := range sorted {
+		l.checker.check()
+		l.L.Lock()
+		locked = append(locked, l.L)
+	}
+
+	for _, l := range sorted {
+		if err = doRecovered(l, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// doRecovered calls f(&l.data), converting a panic in f into an error
+// the same way Lockable.Do does.
+func doRecovered[T any](l *Lockable[T], f func(*T) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case error:
+				err = e
+			case string:
+				err = errors.New(e)
+			default:
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	return f(&l.data)
+}
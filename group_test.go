@@ -0,0 +1,157 @@
+package lockable_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/weiwenchen2022/lockable"
+)
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	l1 := New[int](new(sync.Mutex), 0)
+	l2 := New[int](new(sync.Mutex), 0)
+
+	var g Group
+	Add(&g, l1, false, func(v *int) error {
+		*v = 1
+		return nil
+	})
+	Add(&g, l2, false, func(v *int) error {
+		*v = 2
+		return nil
+	})
+
+	if err := g.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	_ = l1.Do(false, func(v *int) error {
+		if *v != 1 {
+			t.Errorf("l1 = %d, want 1", *v)
+		}
+		return nil
+	})
+	_ = l2.Do(false, func(v *int) error {
+		if *v != 2 {
+			t.Errorf("l2 = %d, want 2", *v)
+		}
+		return nil
+	})
+
+	wantErr := errors.New("boom")
+	var g2 Group
+	Add(&g2, l1, false, func(*int) error { return wantErr })
+	Add(&g2, l2, false, func(*int) error { return nil })
+	if err := g2.Wait(); err != wantErr {
+		t.Fatalf("got %v, want %v", err, wantErr)
+	}
+}
+
+func TestDoAll(t *testing.T) {
+	t.Parallel()
+
+	ls := make([]*Lockable[int], 4)
+	for i := range ls {
+		ls[i] = New[int](new(sync.Mutex), 0)
+	}
+
+	err := DoAll(context.Background(), ls, false, func(i int, v *int) error {
+		*v = i
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, l := range ls {
+		_ = l.Do(true, func(v *int) error {
+			if *v != i {
+				t.Errorf("ls[%d] = %d, want %d", i, *v, i)
+			}
+			return nil
+		})
+	}
+
+	wantErr := errors.New("boom")
+	var calls atomic.Int32
+	err = DoAll(context.Background(), ls, false, func(i int, v *int) error {
+		calls.Add(1)
+		if i == 1 {
+			return wantErr
+		}
+		return nil
+	})
+	if err != wantErr {
+		t.Fatalf("got %v, want %v", err, wantErr)
+	}
+}
+
+func TestDoOrdered(t *testing.T) {
+	t.Parallel()
+
+	ls := make([]*Lockable[int], 8)
+	for i := range ls {
+		ls[i] = New[int](new(sync.Mutex), i)
+	}
+
+	sum := 0
+	err := DoOrdered(ls, func(v *int) error {
+		sum += *v
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 0 + 1 + 2 + 3 + 4 + 5 + 6 + 7; sum != want {
+		t.Fatalf("sum = %d, want %d", sum, want)
+	}
+
+	// concurrent DoOrdered calls over the same (reversed) set must not deadlock.
+	reversed := make([]*Lockable[int], len(ls))
+	for i, l := range ls {
+		reversed[len(ls)-1-i] = l
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = DoOrdered(ls, func(*int) error { return nil })
+	}()
+	go func() {
+		defer wg.Done()
+		_ = DoOrdered(reversed, func(*int) error { return nil })
+	}()
+	wg.Wait()
+}
+
+func TestDoOrderedDuplicatePointer(t *testing.T) {
+	t.Parallel()
+
+	l := New[int](new(sync.Mutex), 1)
+
+	calls := 0
+	done := make(chan error, 1)
+	go func() {
+		done <- DoOrdered([]*Lockable[int]{l, l}, func(v *int) error {
+			calls++
+			return nil
+		})
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != 1 {
+			t.Fatalf("f called %d times, want 1", calls)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("DoOrdered deadlocked on a duplicate Lockable pointer")
+	}
+}
@@ -2,6 +2,7 @@
 package lockable
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
@@ -20,8 +21,12 @@ type Lockable[T any] struct {
 	// It must not be changed after first use.
 	L sync.Locker
 
-	data  T
-	rlock sync.Locker
+	data      T
+	rlockOnce sync.Once
+	rlock     sync.Locker
+
+	condOnce sync.Once
+	cond     *sync.Cond
 
 	checker copyChecker
 }
@@ -39,6 +44,20 @@ type rLocker interface {
 	RLocker() sync.Locker
 }
 
+// rLockerFor returns the Locker to use for read-only acquisition of
+// l.L, initializing it from l.L's RLocker method, if any, exactly
+// once. It is safe to call concurrently without l.L held.
+func (l *Lockable[T]) rLockerFor() sync.Locker {
+	l.rlockOnce.Do(func() {
+		if rl, ok := l.L.(rLocker); ok {
+			l.rlock = rl.RLocker()
+		} else {
+			l.rlock = l.L
+		}
+	})
+	return l.rlock
+}
+
 // Do calls the function f while l.L is held.
 //
 // If readOnly is true and l.L implements 'RLocker() sync.Locker' method,
@@ -52,20 +71,118 @@ func (l *Lockable[T]) Do(readOnly bool, f func(*T) error) (err error) {
 
 	lock := l.L
 	if readOnly {
-		lock.Lock()
-		// Check at most once whether l.L is a RLocker.
-		if l.rlock == nil {
-			if rl, ok := l.L.(rLocker); ok {
-				l.rlock = rl.RLocker()
-			} else {
-				l.rlock = l.L
+		lock = l.rLockerFor()
+	}
+
+	lock.Lock()
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case error:
+				err = e
+			case string:
+				err = errors.New(e)
+			default:
+				err = fmt.Errorf("%v", r)
 			}
 		}
 		lock.Unlock()
-		lock = l.rlock
+	}()
+	return f(&l.data)
+}
+
+// ErrTryLockUnsupported is returned by TryDo when l.L does not implement
+// a TryLock() bool method (or, for a read-only call, a TryRLock() bool
+// method).
+var ErrTryLockUnsupported = errors.New("lockable: underlying Locker does not support try-lock")
+
+// tryLocker is implemented by Lockers such as *sync.Mutex that can
+// attempt to acquire the lock without blocking.
+type tryLocker interface {
+	TryLock() bool
+}
+
+// tryRLocker is implemented by Lockers such as *sync.RWMutex that can
+// attempt to acquire a read lock without blocking.
+type tryRLocker interface {
+	TryRLock() bool
+}
+
+// TryDo is like Do but does not block. It calls f and returns (true, f's
+// result) only if the lock was acquired immediately; otherwise it returns
+// (false, nil) without calling f.
+//
+// If readOnly is true, TryDo probes l.L for a TryRLock() bool method;
+// otherwise it probes l.L for a TryLock() bool method. If l.L does not
+// implement the relevant method, TryDo returns (false, ErrTryLockUnsupported).
+func (l *Lockable[T]) TryDo(readOnly bool, f func(*T) error) (ok bool, err error) {
+	l.checker.check()
+
+	var lock sync.Locker
+	if readOnly {
+		rl, supported := l.L.(tryRLocker)
+		if !supported {
+			return false, ErrTryLockUnsupported
+		}
+		if !rl.TryRLock() {
+			return false, nil
+		}
+		lock = l.rLockerFor()
+	} else {
+		tl, supported := l.L.(tryLocker)
+		if !supported {
+			return false, ErrTryLockUnsupported
+		}
+		if !tl.TryLock() {
+			return false, nil
+		}
+		lock = l.L
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case error:
+				err = e
+			case string:
+				err = errors.New(e)
+			default:
+				err = fmt.Errorf("%v", r)
+			}
+		}
+		lock.Unlock()
+	}()
+	return true, f(&l.data)
+}
+
+// DoContext is like Do but races lock acquisition against ctx. If ctx is
+// done before the lock is acquired, DoContext returns ctx.Err() without
+// calling f; the lock, once acquired, is released without f having been
+// called.
+func (l *Lockable[T]) DoContext(ctx context.Context, readOnly bool, f func(*T) error) (err error) {
+	l.checker.check()
+
+	lock := l.L
+	if readOnly {
+		lock = l.rLockerFor()
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		lock.Lock()
+		close(acquired)
+	}()
+
+	select {
+	case <-acquired:
+	case <-ctx.Done():
+		go func() {
+			<-acquired
+			lock.Unlock()
+		}()
+		return ctx.Err()
 	}
 
-	lock.Lock()
 	defer func() {
 		if r := recover(); r != nil {
 			switch e := r.(type) {
@@ -1,6 +1,7 @@
 package lockable_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
@@ -80,6 +81,114 @@ func TestLockableCopy(t *testing.T) {
 	})
 }
 
+func TestLockableTryDo(t *testing.T) {
+	t.Parallel()
+
+	l := New[map[uint64]string](new(sync.Mutex), make(map[uint64]string))
+
+	ok, err := l.TryDo(false, func(m *map[uint64]string) error {
+		(*m)[1] = "hello"
+		return nil
+	})
+	if !ok || err != nil {
+		t.Fatalf("got (%v, %v), expect (true, nil)", ok, err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	started := make(chan struct{})
+	go func() {
+		_ = l.Do(false, func(*map[uint64]string) error {
+			close(started)
+			wg.Wait()
+			return nil
+		})
+	}()
+
+	<-started
+	if ok, err := l.TryDo(false, func(*map[uint64]string) error {
+		t.Fatal("f should not be called while l is locked")
+		return nil
+	}); ok || err != nil {
+		t.Fatalf("got (%v, %v), expect (false, nil)", ok, err)
+	}
+	wg.Done()
+
+	// TryDo on a Locker without a TryLock method.
+	l2 := New[map[uint64]string](noTryLocker{new(sync.Mutex)}, make(map[uint64]string))
+	if _, err := l2.TryDo(false, func(*map[uint64]string) error { return nil }); err != ErrTryLockUnsupported {
+		t.Fatalf("got %v, expect %v", err, ErrTryLockUnsupported)
+	}
+}
+
+// TestLockableTryDoReadOnlyConcurrentFirstUse exercises TryDo(true, ...)
+// from many goroutines on a freshly constructed Lockable, so that the
+// lazy RLocker cache it shares with Do/DoContext is populated
+// concurrently for the first time. Run with -race.
+func TestLockableTryDoReadOnlyConcurrentFirstUse(t *testing.T) {
+	t.Parallel()
+
+	l := New[map[uint64]string](new(sync.RWMutex), make(map[uint64]string))
+
+	const n = 8
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+			_, _ = l.TryDo(true, func(*map[uint64]string) error {
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+}
+
+// noTryLocker wraps a sync.Locker without exposing a TryLock method.
+type noTryLocker struct {
+	sync.Locker
+}
+
+func TestLockableDoContext(t *testing.T) {
+	t.Parallel()
+
+	l := New[map[uint64]string](new(sync.Mutex), make(map[uint64]string))
+
+	if err := l.DoContext(context.Background(), false, func(m *map[uint64]string) error {
+		(*m)[1] = "hello"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	started := make(chan struct{})
+	go func() {
+		_ = l.Do(false, func(*map[uint64]string) error {
+			close(started)
+			wg.Wait()
+			return nil
+		})
+	}()
+
+	<-started
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.DoContext(ctx, false, func(*map[uint64]string) error {
+		t.Fatal("f should not be called before cancellation")
+		return nil
+	}); err != context.DeadlineExceeded {
+		t.Fatalf("got %v, expect %v", err, context.DeadlineExceeded)
+	}
+	wg.Done()
+
+	// the lock acquired after cancellation must still be usable afterward.
+	if err := l.Do(false, func(*map[uint64]string) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // hand-coded concurrent use map
 type syncMap struct {
 	lock sync.Mutex
@@ -0,0 +1,234 @@
+package lockable
+
+import (
+	"sync"
+)
+
+// Map is a Lockable-backed concurrent map. It mirrors the API of
+// sync.Map, but is implemented on top of a single Lockable[map[K]V]
+// guarded by a sync.RWMutex, so Load, Range and the other read-only
+// operations take a read lock instead of sync.Map's lock-free fast
+// path. Map is best suited to workloads that don't fit sync.Map's
+// append-mostly/rarely-updated assumptions.
+//
+// The zero value is not usable; use NewMap.
+type Map[K comparable, V comparable] struct {
+	l *Lockable[map[K]V]
+}
+
+// NewMap returns a new, empty Map.
+func NewMap[K comparable, V comparable]() *Map[K, V] {
+	return &Map[K, V]{l: New[map[K]V](new(sync.RWMutex), make(map[K]V))}
+}
+
+// Load returns the value stored in the map for key, or the zero value
+// if no value is present. ok reports whether value was found.
+func (m *Map[K, V]) Load(key K) (value V, ok bool) {
+	_ = m.l.Do(true, func(data *map[K]V) error {
+		value, ok = (*data)[key]
+		return nil
+	})
+	return
+}
+
+// Store sets the value for key.
+func (m *Map[K, V]) Store(key K, value V) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		(*data)[key] = value
+		return nil
+	})
+}
+
+// LoadOrStore returns the existing value for key if present.
+// Otherwise, it stores and returns value. loaded is true if value
+// was loaded, false if stored.
+func (m *Map[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		if v, ok := (*data)[key]; ok {
+			actual, loaded = v, true
+			return nil
+		}
+		(*data)[key] = value
+		actual = value
+		return nil
+	})
+	return
+}
+
+// LoadAndDelete deletes the value for key, returning the previous
+// value if any. loaded reports whether key was present.
+func (m *Map[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		value, loaded = (*data)[key]
+		if loaded {
+			delete(*data, key)
+		}
+		return nil
+	})
+	return
+}
+
+// Delete deletes the value for key.
+func (m *Map[K, V]) Delete(key K) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		delete(*data, key)
+		return nil
+	})
+}
+
+// Swap stores value for key and returns the previous value if any.
+// loaded reports whether key was present.
+func (m *Map[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		previous, loaded = (*data)[key]
+		(*data)[key] = value
+		return nil
+	})
+	return
+}
+
+// CompareAndSwap swaps the old and new values for key if the value
+// stored for key is equal to old.
+func (m *Map[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		if v, ok := (*data)[key]; ok && v == old {
+			(*data)[key] = new
+			swapped = true
+		}
+		return nil
+	})
+	return
+}
+
+// CompareAndDelete deletes the entry for key if its value is equal
+// to old.
+func (m *Map[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	_ = m.l.Do(false, func(data *map[K]V) error {
+		if v, ok := (*data)[key]; ok && v == old {
+			delete(*data, key)
+			deleted = true
+		}
+		return nil
+	})
+	return
+}
+
+// Range calls f sequentially for each key and value present in the
+// map. If f returns false, Range stops the iteration. Range holds the
+// map's read lock while f runs, so f must not call any method of m:
+// unlike sync.Map.Range, such a call can deadlock.
+func (m *Map[K, V]) Range(f func(key K, value V) bool) {
+	_ = m.l.Do(true, func(data *map[K]V) error {
+		for k, v := range *data {
+			if !f(k, v) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// ShardedMap is a Map that fans out to n independent shards keyed by
+// hash(K) % n, trading a single point of contention for reduced
+// contention on write-heavy workloads. Its zero value is not usable;
+// use NewShardedMap.
+type ShardedMap[K comparable, V comparable] struct {
+	shards []*Map[K, V]
+	hash   func(K) uint64
+}
+
+// NewShardedMap returns a new ShardedMap with n shards, using hash to
+// pick a key's shard. n <= 0 is treated as 1. hash is called on every
+// operation, so it must be cheap and allocation-free; StringHash is
+// provided for string keys.
+func NewShardedMap[K comparable, V comparable](n int, hash func(K) uint64) *ShardedMap[K, V] {
+	if n <= 0 {
+		n = 1
+	}
+	shards := make([]*Map[K, V], n)
+	for i := range shards {
+		shards[i] = NewMap[K, V]()
+	}
+	return &ShardedMap[K, V]{shards: shards, hash: hash}
+}
+
+// shard returns the Map responsible for key.
+func (m *ShardedMap[K, V]) shard(key K) *Map[K, V] {
+	return m.shards[m.hash(key)%uint64(len(m.shards))]
+}
+
+// StringHash is an FNV-1a hash suitable for use as NewShardedMap's
+// hash function when K is string. Unlike hashing through fmt and a
+// hash.Hash64, it does no reflection and no per-call allocation.
+func StringHash(s string) uint64 {
+	const (
+		offset64 = 14695981039346656037
+		prime64  = 1099511628211
+	)
+
+	h := uint64(offset64)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= prime64
+	}
+	return h
+}
+
+// Load is like Map.Load.
+func (m *ShardedMap[K, V]) Load(key K) (value V, ok bool) {
+	return m.shard(key).Load(key)
+}
+
+// Store is like Map.Store.
+func (m *ShardedMap[K, V]) Store(key K, value V) {
+	m.shard(key).Store(key, value)
+}
+
+// LoadOrStore is like Map.LoadOrStore.
+func (m *ShardedMap[K, V]) LoadOrStore(key K, value V) (actual V, loaded bool) {
+	return m.shard(key).LoadOrStore(key, value)
+}
+
+// LoadAndDelete is like Map.LoadAndDelete.
+func (m *ShardedMap[K, V]) LoadAndDelete(key K) (value V, loaded bool) {
+	return m.shard(key).LoadAndDelete(key)
+}
+
+// Delete is like Map.Delete.
+func (m *ShardedMap[K, V]) Delete(key K) {
+	m.shard(key).Delete(key)
+}
+
+// Swap is like Map.Swap.
+func (m *ShardedMap[K, V]) Swap(key K, value V) (previous V, loaded bool) {
+	return m.shard(key).Swap(key, value)
+}
+
+// CompareAndSwap is like Map.CompareAndSwap.
+func (m *ShardedMap[K, V]) CompareAndSwap(key K, old, new V) (swapped bool) {
+	return m.shard(key).CompareAndSwap(key, old, new)
+}
+
+// CompareAndDelete is like Map.CompareAndDelete.
+func (m *ShardedMap[K, V]) CompareAndDelete(key K, old V) (deleted bool) {
+	return m.shard(key).CompareAndDelete(key, old)
+}
+
+// Range calls f sequentially for each key and value present across
+// all shards, in no particular order. If f returns false, Range
+// stops the iteration.
+func (m *ShardedMap[K, V]) Range(f func(key K, value V) bool) {
+	for _, s := range m.shards {
+		stop := false
+		s.Range(func(k K, v V) bool {
+			if !f(k, v) {
+				stop = true
+				return false
+			}
+			return true
+		})
+		if stop {
+			break
+		}
+	}
+}
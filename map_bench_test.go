@@ -0,0 +1,216 @@
+package lockable_test
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/weiwenchen2022/lockable"
+)
+
+// mapLike is the common surface benchmarked below, implemented by
+// Map, ShardedMap, sync.Map and a hand-rolled RWMutex map.
+type mapLike interface {
+	Load(key string) (value int, ok bool)
+	Store(key string, value int)
+	LoadOrStore(key string, value int) (actual int, loaded bool)
+	Delete(key string)
+}
+
+// rwMutexMap is a plain sync.RWMutex-guarded map, used as a baseline
+// comparable to Map's own implementation strategy.
+type rwMutexMap struct {
+	mu sync.RWMutex
+	m  map[string]int
+}
+
+func newRWMutexMap() *rwMutexMap {
+	return &rwMutexMap{m: make(map[string]int)}
+}
+
+func (m *rwMutexMap) Load(key string) (value int, ok bool) {
+	m.mu.RLock()
+	value, ok = m.m[key]
+	m.mu.RUnlock()
+	return
+}
+
+func (m *rwMutexMap) Store(key string, value int) {
+	m.mu.Lock()
+	m.m[key] = value
+	m.mu.Unlock()
+}
+
+func (m *rwMutexMap) LoadOrStore(key string, value int) (actual int, loaded bool) {
+	m.mu.Lock()
+	if v, ok := m.m[key]; ok {
+		m.mu.Unlock()
+		return v, true
+	}
+	m.m[key] = value
+	m.mu.Unlock()
+	return value, false
+}
+
+func (m *rwMutexMap) Delete(key string) {
+	m.mu.Lock()
+	delete(m.m, key)
+	m.mu.Unlock()
+}
+
+// syncMapAdapter adapts sync.Map to mapLike.
+type syncMapAdapter struct {
+	m sync.Map
+}
+
+func (m *syncMapAdapter) Load(key string) (value int, ok bool) {
+	v, ok := m.m.Load(key)
+	if !ok {
+		return 0, false
+	}
+	return v.(int), true
+}
+
+func (m *syncMapAdapter) Store(key string, value int) {
+	m.m.Store(key, value)
+}
+
+func (m *syncMapAdapter) LoadOrStore(key string, value int) (actual int, loaded bool) {
+	v, loaded := m.m.LoadOrStore(key, value)
+	return v.(int), loaded
+}
+
+func (m *syncMapAdapter) Delete(key string) {
+	m.m.Delete(key)
+}
+
+func benchMaps() map[string]func() mapLike {
+	return map[string]func() mapLike{
+		"Map": func() mapLike { return NewMap[string, int]() },
+		"ShardedMap": func() mapLike {
+			return NewShardedMap[string, int](16, StringHash)
+		},
+		"sync.Map":   func() mapLike { return new(syncMapAdapter) },
+		"RWMutexMap": func() mapLike { return newRWMutexMap() },
+	}
+}
+
+func BenchmarkStoreDelete(b *testing.B) {
+	for name, newMap := range benchMaps() {
+		b.Run(name, func(b *testing.B) {
+			m := newMap()
+			b.ResetTimer()
+
+			var i atomic.Uint64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					key := strconv.FormatUint(i.Add(1), 10)
+					m.Store(key, 1)
+					m.Delete(key)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkLoadOrStoreDelete(b *testing.B) {
+	for name, newMap := range benchMaps() {
+		b.Run(name, func(b *testing.B) {
+			m := newMap()
+			b.ResetTimer()
+
+			var i atomic.Uint64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					key := strconv.FormatUint(i.Add(1), 10)
+					m.LoadOrStore(key, 1)
+					m.Delete(key)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkLookupPositive(b *testing.B) {
+	for name, newMap := range benchMaps() {
+		b.Run(name, func(b *testing.B) {
+			m := newMap()
+			const n = 1 << 10
+			keys := make([]string, n)
+			for i := range keys {
+				keys[i] = strconv.Itoa(i)
+				m.Store(keys[i], i)
+			}
+			b.ResetTimer()
+
+			var i atomic.Uint64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					key := keys[i.Add(1)%n]
+					if _, ok := m.Load(key); !ok {
+						b.Fatalf("missing key %q", key)
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkLookupNegative(b *testing.B) {
+	for name, newMap := range benchMaps() {
+		b.Run(name, func(b *testing.B) {
+			m := newMap()
+			const n = 1 << 10
+			for i := 0; i < n; i++ {
+				m.Store(strconv.Itoa(i), i)
+			}
+			b.ResetTimer()
+
+			var i atomic.Uint64
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					key := "absent-" + strconv.FormatUint(i.Add(1), 10)
+					if _, ok := m.Load(key); ok {
+						b.Fatalf("unexpected key %q", key)
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkConcurrentFixedKeys mirrors the workload matrix used to
+// characterize sync.Map-style structures: a fixed key set is read
+// and written concurrently, with writesPercent of operations being
+// writes.
+func BenchmarkConcurrentFixedKeys(b *testing.B) {
+	const n = 1 << 10
+	for _, writesPercent := range []int{1, 10} {
+		for name, newMap := range benchMaps() {
+			b.Run(fmt.Sprintf("%s/FixedKeys_%dPercentWrites", name, writesPercent), func(b *testing.B) {
+				m := newMap()
+				keys := make([]string, n)
+				for i := range keys {
+					keys[i] = strconv.Itoa(i)
+					m.Store(keys[i], i)
+				}
+				b.ResetTimer()
+
+				var i atomic.Uint64
+				b.RunParallel(func(pb *testing.PB) {
+					for pb.Next() {
+						j := i.Add(1)
+						key := keys[j%n]
+						if int(j)%100 < writesPercent {
+							m.Store(key, int(j))
+						} else {
+							m.Load(key)
+						}
+					}
+				})
+			})
+		}
+	}
+}
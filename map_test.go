@@ -0,0 +1,89 @@
+package lockable_test
+
+import (
+	"testing"
+
+	. "github.com/weiwenchen2022/lockable"
+)
+
+func TestMap(t *testing.T) {
+	t.Parallel()
+
+	m := NewMap[string, int]()
+
+	if _, ok := m.Load("a"); ok {
+		t.Fatal("Load on empty map found a value")
+	}
+
+	m.Store("a", 1)
+	if v, ok := m.Load("a"); !ok || v != 1 {
+		t.Fatalf("Load(%q) = (%v, %v), want (1, true)", "a", v, ok)
+	}
+
+	if actual, loaded := m.LoadOrStore("a", 2); loaded != true || actual != 1 {
+		t.Fatalf("LoadOrStore on existing key = (%v, %v), want (1, true)", actual, loaded)
+	}
+	if actual, loaded := m.LoadOrStore("b", 2); loaded != false || actual != 2 {
+		t.Fatalf("LoadOrStore on new key = (%v, %v), want (2, false)", actual, loaded)
+	}
+
+	if previous, loaded := m.Swap("a", 3); !loaded || previous != 1 {
+		t.Fatalf("Swap = (%v, %v), want (1, true)", previous, loaded)
+	}
+
+	if !m.CompareAndSwap("a", 3, 4) {
+		t.Fatal("CompareAndSwap with matching old value failed")
+	}
+	if m.CompareAndSwap("a", 3, 5) {
+		t.Fatal("CompareAndSwap with stale old value succeeded")
+	}
+
+	if v, loaded := m.LoadAndDelete("a"); !loaded || v != 4 {
+		t.Fatalf("LoadAndDelete = (%v, %v), want (4, true)", v, loaded)
+	}
+	if _, ok := m.Load("a"); ok {
+		t.Fatal("key still present after LoadAndDelete")
+	}
+
+	if m.CompareAndDelete("b", 1) {
+		t.Fatal("CompareAndDelete with stale old value succeeded")
+	}
+	if !m.CompareAndDelete("b", 2) {
+		t.Fatal("CompareAndDelete with matching old value failed")
+	}
+
+	m.Store("x", 10)
+	m.Store("y", 20)
+	seen := map[string]int{}
+	m.Range(func(k string, v int) bool {
+		seen[k] = v
+		return true
+	})
+	if len(seen) != 2 || seen["x"] != 10 || seen["y"] != 20 {
+		t.Fatalf("Range saw %v, want {x:10 y:20}", seen)
+	}
+}
+
+func TestShardedMap(t *testing.T) {
+	t.Parallel()
+
+	m := NewShardedMap[string, int](4, StringHash)
+
+	for i := 0; i < 100; i++ {
+		m.Store(string(rune('a'+i%26))+string(rune('0'+i%10)), i)
+	}
+
+	count := 0
+	m.Range(func(string, int) bool {
+		count++
+		return true
+	})
+	if count == 0 {
+		t.Fatal("Range over ShardedMap saw no entries")
+	}
+
+	m.Delete("a0")
+	if _, ok := m.Load("a0"); ok {
+		t.Fatal("key still present after Delete")
+	}
+}
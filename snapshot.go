@@ -0,0 +1,85 @@
+package lockable
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Cloner is implemented by types that know how to produce an
+// independent copy of themselves. SnapshotLockable's UpdateCloned
+// helper uses it so a snapshot held behind a pointer (or containing
+// slices/maps) can be safely copied before being mutated.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// SnapshotLockable holds a T behind an atomic.Pointer, for read-mostly
+// workloads such as config or routing tables that are read on every
+// request but mutated rarely. Readers call View and see the current
+// snapshot without taking any lock; writers call Update, which takes
+// s.mu, builds a new snapshot from a copy of the current one, and
+// publishes it atomically.
+//
+// A SnapshotLockable must not be copied after first use.
+type SnapshotLockable[T any] struct {
+	noCopy noCopy
+
+	mu sync.Locker
+	v  atomic.Pointer[T]
+
+	checker copyChecker
+}
+
+// NewSnapshot returns a new SnapshotLockable with Locker l, guarding
+// Update, and using data as its initial snapshot.
+func NewSnapshot[T any](l sync.Locker, data T) *SnapshotLockable[T] {
+	s := &SnapshotLockable[T]{mu: l}
+	s.v.Store(&data)
+	return s
+}
+
+// View calls f with the current snapshot. f must not retain or
+// mutate the pointer it's given: the snapshot it points to may be
+// replaced, but never modified, by a concurrent Update.
+func (s *SnapshotLockable[T]) View(f func(*T)) {
+	s.checker.check()
+	f(s.v.Load())
+}
+
+// Update takes s.mu, calls f with a copy of the current snapshot, and
+// publishes f's result as the new snapshot. Because f receives T by
+// value, plain copy semantics are enough when T holds no pointers,
+// slices or maps; otherwise use UpdateCloned.
+func (s *SnapshotLockable[T]) Update(f func(T) T) (err error) {
+	s.checker.check()
+
+	s.mu.Lock()
+	defer func() {
+		if r := recover(); r != nil {
+			switch e := r.(type) {
+			case error:
+				err = e
+			case string:
+				err = errors.New(e)
+			default:
+				err = fmt.Errorf("%v", r)
+			}
+		}
+		s.mu.Unlock()
+	}()
+
+	next := f(*s.v.Load())
+	s.v.Store(&next)
+	return nil
+}
+
+// UpdateCloned is like Update, but passes f a deep copy of the
+// current snapshot made via Clone, for T that hold references which
+// a plain value copy would still share with readers.
+func UpdateCloned[T Cloner[T]](s *SnapshotLockable[T], f func(T) T) error {
+	return s.Update(func(cur T) T {
+		return f(cur.Clone())
+	})
+}
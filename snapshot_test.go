@@ -0,0 +1,80 @@
+package lockable_test
+
+import (
+	"sync"
+	"testing"
+
+	. "github.com/weiwenchen2022/lockable"
+)
+
+func TestSnapshotLockable(t *testing.T) {
+	t.Parallel()
+
+	s := NewSnapshot[[]string](new(sync.Mutex), []string{"a"})
+
+	var got []string
+	s.View(func(v *[]string) {
+		got = *v
+	})
+	if len(got) != 1 || got[0] != "a" {
+		t.Fatalf("View = %v, want [a]", got)
+	}
+
+	if err := s.Update(func(cur []string) []string {
+		return append(append([]string{}, cur...), "b")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	s.View(func(v *[]string) {
+		got = *v
+	})
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("View after Update = %v, want [a b]", got)
+	}
+}
+
+// cloneableConfig is a reference type whose plain value copy would
+// still alias the map underneath, so it implements Cloner.
+type cloneableConfig struct {
+	routes map[string]string
+}
+
+func (c cloneableConfig) Clone() cloneableConfig {
+	routes := make(map[string]string, len(c.routes))
+	for k, v := range c.routes {
+		routes[k] = v
+	}
+	return cloneableConfig{routes: routes}
+}
+
+func TestSnapshotLockableUpdateCloned(t *testing.T) {
+	t.Parallel()
+
+	s := NewSnapshot[cloneableConfig](new(sync.Mutex), cloneableConfig{
+		routes: map[string]string{"/": "home"},
+	})
+
+	var original map[string]string
+	s.View(func(c *cloneableConfig) { original = c.routes })
+
+	err := UpdateCloned(s, func(cur cloneableConfig) cloneableConfig {
+		cur.routes["/about"] = "about"
+		return cur
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var after map[string]string
+	s.View(func(c *cloneableConfig) { after = c.routes })
+	if len(after) != 2 || after["/about"] != "about" {
+		t.Fatalf("routes = %v, want 2 entries including /about", after)
+	}
+
+	// UpdateCloned must have deep-copied routes before mutating it,
+	// so the map backing the original snapshot is untouched.
+	if _, ok := original["/about"]; ok {
+		t.Fatal("UpdateCloned mutated the previous snapshot's map in place")
+	}
+}